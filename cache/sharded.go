@@ -0,0 +1,131 @@
+// Package cache 在 lru.Cache 之上提供一个并发安全的分片缓存。
+//
+// map 本身不是并发安全的，单独加一把大锁又会让所有 key 的读写互相排队，
+// ShardedCache 把 key 按哈希分散到多个 shard，每个 shard 各自持有一把锁和一个
+// 独立的 lru.Cache，这样访问不同 shard 的 key 就可以完全并行。
+package cache
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/Sakura-LF/Go-Cache/lru"
+)
+
+// defaultShardCount 是未指定分片数时的默认值，必须是 2 的幂
+const defaultShardCount = 16
+
+// shard 是一个加锁保护的 lru.StringCache
+type shard struct {
+	mu    sync.Mutex
+	cache *lru.StringCache
+}
+
+// ShardedCache 把 key 空间切分成多个 shard，每个 shard 独立加锁、独立淘汰，
+// 从而让不相关 key 的并发读写不必互相等待。
+type ShardedCache struct {
+	shards []*shard
+	mask   uint32
+}
+
+// NewShardedCache 实例化一个分片缓存，shardCount 必须是 2 的幂，
+// 每个分片的最大内存是 maxBytesPerShard；onEvicted 会在任意分片发生淘汰时被调用。
+func NewShardedCache(shardCount int, maxBytesPerShard int, onEvicted func(key, value string)) *ShardedCache {
+	if shardCount <= 0 || shardCount&(shardCount-1) != 0 {
+		shardCount = defaultShardCount
+	}
+	sc := &ShardedCache{
+		shards: make([]*shard, shardCount),
+		mask:   uint32(shardCount - 1),
+	}
+	for i := range sc.shards {
+		sc.shards[i] = &shard{cache: lru.NewStringCache(maxBytesPerShard, onEvicted)}
+	}
+	return sc
+}
+
+// fnv32 对 key 做哈希，用来决定落在哪个 shard
+func fnv32(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
+
+func (sc *ShardedCache) getShard(key string) *shard {
+	return sc.shards[fnv32(key)&sc.mask]
+}
+
+// Set 写入一个key-value
+func (sc *ShardedCache) Set(key, value string) {
+	s := sc.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Add(key, value)
+}
+
+// Get 查询key所对应的value，命中会提升为MRU
+func (sc *ShardedCache) Get(key string) (value string, ok bool) {
+	s := sc.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+// Peek 查询key所对应的value，但不会提升为MRU，避免一次性扫描污染淘汰顺序
+func (sc *ShardedCache) Peek(key string) (value string, ok bool) {
+	s := sc.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Peek(key)
+}
+
+// Del 删除一个key，存在则返回true
+func (sc *ShardedCache) Del(key string) bool {
+	s := sc.getShard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Remove(key)
+}
+
+// Len 返回所有分片的缓存条目总数
+func (sc *ShardedCache) Len() int {
+	total := 0
+	for _, s := range sc.shards {
+		s.mu.Lock()
+		total += s.cache.Len()
+		s.mu.Unlock()
+	}
+	return total
+}
+
+// Bytes 返回所有分片占用的字节数总和
+func (sc *ShardedCache) Bytes() int {
+	total := 0
+	for _, s := range sc.shards {
+		s.mu.Lock()
+		total += s.cache.Bytes()
+		s.mu.Unlock()
+	}
+	return total
+}
+
+// Range 按分片顺序遍历所有key-value，f返回false时提前终止遍历。
+// 遍历某个分片时该分片会被加锁，不会跨分片加锁，因此f中不应该再次访问ShardedCache。
+func (sc *ShardedCache) Range(f func(key, value string) bool) {
+	for _, s := range sc.shards {
+		if !s.rangeLocked(f) {
+			return
+		}
+	}
+}
+
+func (s *shard) rangeLocked(f func(key, value string) bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cont := true
+	s.cache.Range(func(key, value string) bool {
+		cont = f(key, value)
+		return cont
+	})
+	return cont
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestShardedCacheSetGetDel(t *testing.T) {
+	sc := NewShardedCache(8, 1<<20, nil)
+	sc.Set("k1", "v1")
+
+	if v, ok := sc.Get("k1"); !ok || v != "v1" {
+		t.Fatalf("Get(k1) = %v, %v; want v1, true", v, ok)
+	}
+	if sc.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", sc.Len())
+	}
+	if !sc.Del("k1") {
+		t.Fatalf("Del(k1) = false, want true")
+	}
+	if _, ok := sc.Get("k1"); ok {
+		t.Fatalf("Get(k1) after Del should miss")
+	}
+}
+
+func TestShardedCachePeekDoesNotPromote(t *testing.T) {
+	var evicted []string
+	// 每个分片只能容纳两个 entry，方便观察淘汰顺序
+	sc := NewShardedCache(1, (len("k1")+len("v1"))*2, func(key, _ string) {
+		evicted = append(evicted, key)
+	})
+
+	sc.Set("k1", "v1")
+	sc.Set("k2", "v2")
+	sc.Peek("k1") // Peek不应该提升k1的地位，k1仍是最久未访问的
+	sc.Set("k3", "v3")
+
+	if len(evicted) != 1 || evicted[0] != "k1" {
+		t.Fatalf("evicted = %v, want [k1]; Peek should not promote", evicted)
+	}
+}
+
+func TestShardedCacheConcurrentAccess(t *testing.T) {
+	sc := NewShardedCache(16, 1<<20, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key%d", i)
+			sc.Set(key, key)
+			if v, ok := sc.Get(key); !ok || v != key {
+				t.Errorf("Get(%s) = %v, %v; want %s, true", key, v, ok, key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if sc.Len() != 100 {
+		t.Fatalf("Len() = %d, want 100", sc.Len())
+	}
+}
+
+func TestShardedCacheRange(t *testing.T) {
+	sc := NewShardedCache(4, 1<<20, nil)
+	want := map[string]string{"a": "1", "b": "2", "c": "3"}
+	for k, v := range want {
+		sc.Set(k, v)
+	}
+	got := make(map[string]string)
+	sc.Range(func(key, value string) bool {
+		got[key] = value
+		return true
+	})
+	if len(got) != len(want) {
+		t.Fatalf("Range visited %d entries, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("Range missed %s=%s", k, v)
+		}
+	}
+}
+
+func benchmarkShardedMixedLoad(b *testing.B, shardCount int) {
+	sc := NewShardedCache(shardCount, 1<<20, nil)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		sc.Set(key, key)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := fmt.Sprintf("key%d", i%1000)
+			if i%10 == 0 {
+				sc.Set(key, key)
+			} else {
+				sc.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+// BenchmarkShardedMixedLoad 比较分片数对混合读写吞吐量的影响
+func BenchmarkShardedMixedLoad(b *testing.B) {
+	for _, n := range []int{1, 8, 64} {
+		n := n
+		b.Run(fmt.Sprintf("shards=%d", n), func(b *testing.B) {
+			benchmarkShardedMixedLoad(b, n)
+		})
+	}
+}
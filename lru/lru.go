@@ -1,7 +1,9 @@
 package lru
 
 import (
-	"container/list"
+	"fmt"
+	"sync"
+	"time"
 )
 
 // 最近最少使用，相对于仅考虑时间因素的FIFO和仅考虑访问频率的LFU，LRU算法可以认为是相对平衡的一种淘汰算法。
@@ -9,91 +11,198 @@ import (
 // 算法的实现
 // 1.维护一个队列，如果某条记录被访问了，则移动到队尾，
 // 2.队首则是最近最少访问的数据，淘汰该条记录即可。
+//
+// Cache 内部不再自己维护淘汰顺序，而是把"记录访问"和"挑选淘汰对象"都委托给
+// EvictionPolicy，这样只需要替换策略就能在 FIFO/LRU/LRU-K/TinyLFU 之间切换。
+// Cache 自身维护一把锁，使得单个 Cache 本身就是并发安全的，这是为了让
+// StartJanitor 启动的后台协程可以安全地和前台的 Get/Add 并发执行。
 
-// Cache 核心结构体
-type Cache struct {
-	maxBytes int                      // 最大内存
-	nBytes   int                      // 目前内存
-	ll       *list.List               // 维护的队列
-	cache    map[string]*list.Element // 缓存内的数据
-
-	OnEvicted func(key string, value string)
+// entry 缓存的一条记录
+type entry[K comparable, V Value] struct {
+	key   K
+	value V
 }
 
-// entry 双向链表结点
-// 正常情况下在key里面存数据就可以了
-// 淘汰队首节点时候,需要用key从字典中删除对应的映射
-type entry struct {
-	key   string
-	value string
+// Cache 核心结构体，K 是key的类型，V 必须知道自己的字节长度(实现 Value)
+type Cache[K comparable, V Value] struct {
+	mu sync.Mutex
+
+	maxBytes int                // 最大内存
+	nBytes   int                // 目前内存
+	policy   EvictionPolicy[K]  // 淘汰策略，决定淘汰顺序
+	cache    map[K]*entry[K, V] // 缓存内的数据
+
+	ttl      ttlHeap[K]         // 按到期时间排序的最小堆，配合Janitor做O(k log n)的过期扫描
+	ttlIndex map[K]*ttlEntry[K] // key -> 堆中节点，便于O(log n)更新/删除
+
+	// OnEvicted 在记录被淘汰时调用，reason 说明了淘汰的原因
+	OnEvicted func(key K, value V, reason EvictReason)
 }
 
-// NewCache 实例化Cache
-func NewCache(maxBytes int, OnEvicted func(string, string)) *Cache {
-	return &Cache{
+// NewCache 实例化Cache，默认使用经典 LRU 策略，可通过 WithPolicy 替换
+func NewCache[K comparable, V Value](maxBytes int, onEvicted func(K, V, EvictReason), opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
 		maxBytes:  maxBytes,
-		ll:        list.New(),
-		cache:     make(map[string]*list.Element),
-		OnEvicted: OnEvicted,
+		cache:     make(map[K]*entry[K, V]),
+		ttlIndex:  make(map[K]*ttlEntry[K]),
+		OnEvicted: onEvicted,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if c.policy == nil {
+		c.policy = NewLRUPolicy[K]()
+	}
+	return c
 }
 
-// Get 查询key所对应的value
-// 1.找到对应双向链表的节点
-// 2.将该节点移动到队尾,并且返回查找到的值
-func (c *Cache) Get(key string) (value string, ok bool) {
-	if data, ok := c.cache[key]; ok {
-		// 将该节点移动到队尾
-		c.ll.MoveToFront(data)
-		// 返回k,value
-		kv := data.Value.(*entry)
-		return kv.value, true
+// Get 查询key所对应的value；如果该记录的TTL已经到期，视为未命中并惰性淘汰它
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.cache[key]
+	if !ok {
+		return
 	}
-	return
-}
-
-// RemoveOldest 缓存淘汰
-func (c *Cache) RemoveOldest() {
-	// 取到队首节点
-	ele := c.ll.Back()
-	if ele != nil {
-		// 删除队首节点
-		c.ll.Remove(ele)
-		kv := ele.Value.(*entry)
-		// 删除cache中该节点的映射关系
-		delete(c.cache, kv.key)
-		// 更新缓存当前的字节数
-		c.nBytes -= len(kv.key) + len(kv.value)
-		// 若回调函数不为nil,缓存淘汰的时候调用回调函数
-		if c.OnEvicted != nil {
-			c.OnEvicted(kv.key, kv.value)
-		}
+	if c.expiredLocked(key) {
+		c.removeLocked(key, EvictTTL)
+		var zero V
+		return zero, false
+	}
+	c.policy.OnAccess(key)
+	return e.value, true
+}
+
+// Peek 查询key所对应的value，但不会触发策略的访问记录(不会提升为MRU)，
+// 也不会淘汰已经过期的记录，只是如实反映出它已经失效。
+// 用于调用方想要窥探缓存内容又不想影响淘汰顺序的场景。
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.cache[key]
+	if !ok || c.expiredLocked(key) {
+		var zero V
+		return zero, false
 	}
+	return e.value, true
 }
 
-func (c *Cache) Add(key string, value string) {
-	// 如果key存在
-	if ele, ok := c.cache[key]; ok {
-		// 将该节点移动到队尾
-		c.ll.MoveToFront(ele)
-		// 获取entry节点
-		kv := ele.Value.(*entry)
-		c.nBytes += len(value) - len(kv.value)
-		// 更新对应节点的值
-		kv.value = value
-	} else { // key不存在
-		// 向队尾添加节点
-		ele := c.ll.PushFront(&entry{key, value})
-		c.cache[key] = ele
-		c.nBytes += len(key) + len(value)
+// RemoveOldest 按照当前策略淘汰一条记录
+func (c *Cache[K, V]) RemoveOldest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok := c.policy.Victim()
+	if !ok {
+		return
 	}
+	c.removeLocked(key, c.capacityEvictReason())
+}
+
+// capacityEvictReason 按当前使用的淘汰策略细化容量淘汰的原因：
+// 经典 LRU 策略报告 EvictLRU，其余策略统一报告 EvictCapacity
+func (c *Cache[K, V]) capacityEvictReason() EvictReason {
+	if _, ok := c.policy.(*LRUPolicy[K]); ok {
+		return EvictLRU
+	}
+	return EvictCapacity
+}
+
+// Add 写入一个key-value，value必须实现 Value 接口以便计算占用的字节数；
+// 该记录永不因TTL过期，如需自动过期请使用 AddWithTTL
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(key, value, time.Time{})
+}
+
+// AddWithTTL 写入一个key-value，并在 ttl 之后让它自动失效
+func (c *Cache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(key, value, time.Now().Add(ttl))
+}
+
+func (c *Cache[K, V]) addLocked(key K, value V, expiresAt time.Time) {
+	if e, ok := c.cache[key]; ok {
+		c.nBytes += value.Len() - e.value.Len()
+		e.value = value
+		c.policy.OnAccess(key)
+	} else {
+		c.cache[key] = &entry[K, V]{key, value}
+		c.nBytes += keySize(key) + value.Len()
+		c.policy.OnAdd(key, keySize(key)+value.Len())
+	}
+	c.setExpiryLocked(key, expiresAt)
 	// 判断是否超出内存
 	for c.maxBytes != 0 && c.maxBytes < c.nBytes {
-		c.RemoveOldest()
+		victim, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		c.removeLocked(victim, c.capacityEvictReason())
+	}
+}
+
+// Remove 主动删除一个key，存在则返回true
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.cache[key]; !ok {
+		return false
+	}
+	c.removeLocked(key, EvictExplicit)
+	return true
+}
+
+// removeLocked 在持有c.mu的前提下把key从缓存、策略和TTL堆里移除，并触发OnEvicted
+func (c *Cache[K, V]) removeLocked(key K, reason EvictReason) {
+	e, ok := c.cache[key]
+	if !ok {
+		return
+	}
+	delete(c.cache, key)
+	c.nBytes -= keySize(key) + e.value.Len()
+	c.policy.OnRemove(key)
+	c.clearExpiryLocked(key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(e.key, e.value, reason)
 	}
 }
 
 // Len the number of cache entries
-func (c *Cache) Len() int {
-	return c.ll.Len()
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cache)
+}
+
+// Bytes 返回当前缓存占用的字节数
+func (c *Cache[K, V]) Bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nBytes
+}
+
+// Range 遍历缓存中的每一项，不保证顺序；f返回false时提前终止遍历
+func (c *Cache[K, V]) Range(f func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.cache {
+		if !f(key, e.value) {
+			return
+		}
+	}
+}
+
+// keySize 估算一个key占用的字节数，用于内存记账。
+// string/[]byte 直接取长度，其余类型退化为其%v格式化后的长度作为近似值。
+func keySize[K comparable](key K) int {
+	switch k := any(key).(type) {
+	case string:
+		return len(k)
+	case []byte:
+		return len(k)
+	default:
+		return len(fmt.Sprintf("%v", k))
+	}
 }
@@ -0,0 +1,24 @@
+package lru
+
+// EvictionPolicy 定义了缓存"淘汰谁"以及"如何记录访问"的行为，
+// Cache 本身只负责字节计数和存储，具体淘汰算法由 EvictionPolicy 的实现决定。
+type EvictionPolicy[K comparable] interface {
+	// OnAdd 在一个新的 key 被写入缓存时调用
+	OnAdd(key K, size int)
+	// OnAccess 在 key 被访问命中(Get 或重复 Add)时调用
+	OnAccess(key K)
+	// OnRemove 在 key 被移除(无论是主动删除还是被淘汰)后调用，用于清理内部状态
+	OnRemove(key K)
+	// Victim 返回下一个应当被淘汰的 key；缓存为空时 ok 为 false
+	Victim() (key K, ok bool)
+}
+
+// Option 用于在 NewCache 时定制 Cache 的行为
+type Option[K comparable, V Value] func(*Cache[K, V])
+
+// WithPolicy 指定 Cache 使用的淘汰策略，不指定时默认使用经典 LRU
+func WithPolicy[K comparable, V Value](policy EvictionPolicy[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.policy = policy
+	}
+}
@@ -0,0 +1,44 @@
+package lru
+
+import "container/list"
+
+// FIFOPolicy 按照写入顺序淘汰，是最朴素的淘汰算法：
+// 先进先出，命中不会改变队列里的顺序。
+type FIFOPolicy[K comparable] struct {
+	ll   *list.List
+	elem map[K]*list.Element
+}
+
+// NewFIFOPolicy 实例化一个 FIFO 淘汰策略
+func NewFIFOPolicy[K comparable]() *FIFOPolicy[K] {
+	return &FIFOPolicy[K]{
+		ll:   list.New(),
+		elem: make(map[K]*list.Element),
+	}
+}
+
+func (p *FIFOPolicy[K]) OnAdd(key K, _ int) {
+	if _, ok := p.elem[key]; ok {
+		return
+	}
+	p.elem[key] = p.ll.PushFront(key)
+}
+
+// OnAccess FIFO 不关心命中，队列顺序不变
+func (p *FIFOPolicy[K]) OnAccess(K) {}
+
+func (p *FIFOPolicy[K]) OnRemove(key K) {
+	if ele, ok := p.elem[key]; ok {
+		p.ll.Remove(ele)
+		delete(p.elem, key)
+	}
+}
+
+func (p *FIFOPolicy[K]) Victim() (key K, ok bool) {
+	ele := p.ll.Back()
+	if ele == nil {
+		var zero K
+		return zero, false
+	}
+	return ele.Value.(K), true
+}
@@ -0,0 +1,48 @@
+package lru
+
+import "container/list"
+
+// LRUPolicy 是经典的最近最少使用算法：命中的 key 被移动到队尾，
+// 淘汰时取队首，即最久未被访问的 key。这是 Cache 的默认策略。
+type LRUPolicy[K comparable] struct {
+	ll   *list.List
+	elem map[K]*list.Element
+}
+
+// NewLRUPolicy 实例化一个 LRU 淘汰策略
+func NewLRUPolicy[K comparable]() *LRUPolicy[K] {
+	return &LRUPolicy[K]{
+		ll:   list.New(),
+		elem: make(map[K]*list.Element),
+	}
+}
+
+func (p *LRUPolicy[K]) OnAdd(key K, _ int) {
+	if ele, ok := p.elem[key]; ok {
+		p.ll.MoveToFront(ele)
+		return
+	}
+	p.elem[key] = p.ll.PushFront(key)
+}
+
+func (p *LRUPolicy[K]) OnAccess(key K) {
+	if ele, ok := p.elem[key]; ok {
+		p.ll.MoveToFront(ele)
+	}
+}
+
+func (p *LRUPolicy[K]) OnRemove(key K) {
+	if ele, ok := p.elem[key]; ok {
+		p.ll.Remove(ele)
+		delete(p.elem, key)
+	}
+}
+
+func (p *LRUPolicy[K]) Victim() (key K, ok bool) {
+	ele := p.ll.Back()
+	if ele == nil {
+		var zero K
+		return zero, false
+	}
+	return ele.Value.(K), true
+}
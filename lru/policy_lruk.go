@@ -0,0 +1,148 @@
+package lru
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// DefaultLRUK 是 LRU-K 默认的 K 值
+const DefaultLRUK = 2
+
+// lruKEntry 记录一个 key 最近 K 次访问的逻辑时间戳，hist 按从旧到新排列，
+// 长度不超过 K；未达到 K 次访问前 entry 处于 cold 状态。
+type lruKEntry[K comparable] struct {
+	key   K
+	hist  []uint64
+	index int // 在 hot 堆中的位置，-1 表示不在堆中
+}
+
+// lruKHeap 是按 hist[0](即第 K 次最近访问时间) 升序排列的最小堆，
+// 堆顶就是"第 K 次最近访问离现在最久"的 entry，也就是下一个该淘汰的 hot key。
+type lruKHeap[K comparable] []*lruKEntry[K]
+
+func (h lruKHeap[K]) Len() int           { return len(h) }
+func (h lruKHeap[K]) Less(i, j int) bool { return h[i].hist[0] < h[j].hist[0] }
+func (h lruKHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *lruKHeap[K]) Push(x interface{}) {
+	e := x.(*lruKEntry[K])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *lruKHeap[K]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+// LRUKPolicy 只淘汰满足 K 次访问历史的 entry 中最旧的一个：
+// 访问次数不足 K 的 entry 视为"冷" entry，退化为按最近一次访问排序的 LRU，
+// 并且冷 entry 总是优先于热 entry 被淘汰，用来抵抗一次性扫描污染缓存。
+// 冷 entry 用 container/list 维护先后顺序，插入/访问/移除都是 O(1)，
+// 这样一次性扫描产生的大量冷 entry 不会退化成线性重排。
+type LRUKPolicy[K comparable] struct {
+	k     int
+	clock uint64
+
+	cold     *list.List // 冷 entry，按最近访问时间排列（front 最旧）
+	coldElem map[K]*list.Element
+	hist     map[K][]uint64 // 冷 entry 的访问历史
+
+	hot     lruKHeap[K]
+	hotElem map[K]*lruKEntry[K]
+}
+
+// NewLRUKPolicy 实例化一个 LRU-K 淘汰策略，k<=1 时退化为 1，即等价于 LRU
+func NewLRUKPolicy[K comparable](k int) *LRUKPolicy[K] {
+	if k <= 1 {
+		k = 1
+	}
+	return &LRUKPolicy[K]{
+		k:        k,
+		cold:     list.New(),
+		coldElem: make(map[K]*list.Element),
+		hist:     make(map[K][]uint64),
+		hot:      make(lruKHeap[K], 0),
+		hotElem:  make(map[K]*lruKEntry[K]),
+	}
+}
+
+func (p *LRUKPolicy[K]) touch(key K) {
+	p.clock++
+	if e, ok := p.hotElem[key]; ok {
+		e.hist = append(e.hist[1:], p.clock)
+		heap.Fix(&p.hot, e.index)
+		return
+	}
+	h, ok := p.hist[key]
+	if !ok {
+		p.coldElem[key] = p.cold.PushBack(key)
+		p.hist[key] = []uint64{p.clock}
+		if p.k <= 1 {
+			p.graduate(key)
+		}
+		return
+	}
+	h = append(h, p.clock)
+	if ele, ok := p.coldElem[key]; ok {
+		p.cold.MoveToBack(ele)
+	}
+	if len(h) >= p.k {
+		p.hist[key] = h
+		p.graduate(key)
+		return
+	}
+	p.hist[key] = h
+}
+
+// graduate 把访问次数达到 K 的冷 entry 迁移到 hot 堆中
+func (p *LRUKPolicy[K]) graduate(key K) {
+	if ele, ok := p.coldElem[key]; ok {
+		p.cold.Remove(ele)
+		delete(p.coldElem, key)
+	}
+	h := p.hist[key]
+	delete(p.hist, key)
+	e := &lruKEntry[K]{key: key, hist: h}
+	heap.Push(&p.hot, e)
+	p.hotElem[key] = e
+}
+
+func (p *LRUKPolicy[K]) OnAdd(key K, _ int) {
+	p.touch(key)
+}
+
+func (p *LRUKPolicy[K]) OnAccess(key K) {
+	p.touch(key)
+}
+
+func (p *LRUKPolicy[K]) OnRemove(key K) {
+	if e, ok := p.hotElem[key]; ok {
+		heap.Remove(&p.hot, e.index)
+		delete(p.hotElem, key)
+		return
+	}
+	if ele, ok := p.coldElem[key]; ok {
+		p.cold.Remove(ele)
+		delete(p.coldElem, key)
+		delete(p.hist, key)
+	}
+}
+
+func (p *LRUKPolicy[K]) Victim() (key K, ok bool) {
+	if ele := p.cold.Front(); ele != nil {
+		return ele.Value.(K), true
+	}
+	if len(p.hot) > 0 {
+		return p.hot[0].key, true
+	}
+	var zero K
+	return zero, false
+}
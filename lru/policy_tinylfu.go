@@ -0,0 +1,182 @@
+package lru
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+)
+
+const (
+	cmsDepth       = 4    // count-min sketch 的行数
+	cmsWidth       = 1024 // count-min sketch 每行的宽度
+	cmsMaxCounter  = 15   // 每个计数器用 4 bit 模拟，最大计数 15
+	cmsResetAfter  = cmsWidth * cmsDepth * 10
+	doorkeeperBits = cmsWidth * 8
+)
+
+// countMinSketch 是一个近似频率计数器，用少量内存估计一个 key 被访问过多少次，
+// 允许高估但不允许严重低估，足够用来比较两个 key 谁更"热门"。
+type countMinSketch struct {
+	counters  [cmsDepth][cmsWidth]uint8
+	additions int
+}
+
+func newCountMinSketch() *countMinSketch {
+	return &countMinSketch{}
+}
+
+func (s *countMinSketch) hash(key string, row int) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{byte(row)})
+	h.Write([]byte(key))
+	return h.Sum32() % cmsWidth
+}
+
+func (s *countMinSketch) Increment(key string) {
+	for i := 0; i < cmsDepth; i++ {
+		idx := s.hash(key, i)
+		if s.counters[i][idx] < cmsMaxCounter {
+			s.counters[i][idx]++
+		}
+	}
+	s.additions++
+	if s.additions >= cmsResetAfter {
+		s.reset()
+	}
+}
+
+// reset 对所有计数器做一次减半老化，防止历史频率永远压制新近的热点
+func (s *countMinSketch) reset() {
+	for i := 0; i < cmsDepth; i++ {
+		for j := 0; j < cmsWidth; j++ {
+			s.counters[i][j] /= 2
+		}
+	}
+	s.additions /= 2
+}
+
+func (s *countMinSketch) Estimate(key string) uint8 {
+	min := uint8(cmsMaxCounter)
+	for i := 0; i < cmsDepth; i++ {
+		if c := s.counters[i][s.hash(key, i)]; c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+// doorkeeper 是一个简单的 bloom filter，用来要求一个 key 至少出现两次
+// 才会被计入 count-min sketch，从而过滤掉绝大多数只访问一次的噪声 key。
+type doorkeeper struct {
+	bits []bool
+}
+
+func newDoorkeeper() *doorkeeper {
+	return &doorkeeper{bits: make([]bool, doorkeeperBits)}
+}
+
+func (d *doorkeeper) hash(key string, seed byte) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{seed})
+	h.Write([]byte(key))
+	return h.Sum32() % uint32(len(d.bits))
+}
+
+// Seen 返回 key 是否已经出现过，并把 key 记录进去
+func (d *doorkeeper) Seen(key string) bool {
+	i1, i2 := d.hash(key, 1), d.hash(key, 2)
+	seen := d.bits[i1] && d.bits[i2]
+	d.bits[i1] = true
+	d.bits[i2] = true
+	return seen
+}
+
+// keyToString 把任意 comparable 的 key 转成字符串以便哈希，string 直接使用自身，
+// 其余类型退化为%v格式化，足够用来做频率估计的哈希输入。
+func keyToString[K comparable](key K) string {
+	if s, ok := any(key).(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+// TinyLFUPolicy 在 LRU 队列前加了一层准入判断：新 key 只有比即将被淘汰的
+// LRU 队尾 key 更"热"（count-min sketch 估计的访问频率更高）才允许进入缓存，
+// 否则直接丢弃新 key，从而避免突发的一次性扫描把常驻的热点数据挤出去。
+type TinyLFUPolicy[K comparable] struct {
+	window     *doorkeeper
+	sketch     *countMinSketch
+	ll         *list.List
+	elem       map[K]*list.Element
+	lastAdded  K
+	hasPending bool
+}
+
+// NewTinyLFUPolicy 实例化一个 TinyLFU 准入策略
+func NewTinyLFUPolicy[K comparable]() *TinyLFUPolicy[K] {
+	return &TinyLFUPolicy[K]{
+		window: newDoorkeeper(),
+		sketch: newCountMinSketch(),
+		ll:     list.New(),
+		elem:   make(map[K]*list.Element),
+	}
+}
+
+func (p *TinyLFUPolicy[K]) record(key K) {
+	ks := keyToString(key)
+	if p.window.Seen(ks) {
+		p.sketch.Increment(ks)
+	}
+}
+
+func (p *TinyLFUPolicy[K]) OnAdd(key K, _ int) {
+	p.record(key)
+	if ele, ok := p.elem[key]; ok {
+		p.ll.MoveToFront(ele)
+		return
+	}
+	p.elem[key] = p.ll.PushFront(key)
+	p.lastAdded = key
+	p.hasPending = true
+}
+
+func (p *TinyLFUPolicy[K]) OnAccess(key K) {
+	p.record(key)
+	if ele, ok := p.elem[key]; ok {
+		p.ll.MoveToFront(ele)
+	}
+}
+
+func (p *TinyLFUPolicy[K]) OnRemove(key K) {
+	if ele, ok := p.elem[key]; ok {
+		p.ll.Remove(ele)
+		delete(p.elem, key)
+	}
+	if p.lastAdded == key {
+		p.hasPending = false
+	}
+}
+
+// Victim 优先淘汰队尾的 LRU 受害者，但如果还有一个刚写入、尚未裁决的新 key
+// 且它不是队尾，则比较新 key 与当前队尾(旧 key)的估计频率，新 key 只有严格更高
+// 时才淘汰队尾，否则淘汰新 key。
+// 准入对决只会被裁决一次：不管新 key 赢了还是输了，裁决发生后都立刻清除
+// hasPending，否则一旦新 key 赢了(队尾旧 key 被淘汰)，hasPending 会一直
+// 粘在这个已经变成普通常驻 entry 的 lastAdded 上，污染后续不相关的 Victim()
+// 调用(比如 RemoveOldest，或者是因为某个已存在 key 原地增大触发的淘汰)。
+func (p *TinyLFUPolicy[K]) Victim() (key K, ok bool) {
+	ele := p.ll.Back()
+	if ele == nil {
+		var zero K
+		return zero, false
+	}
+	tail := ele.Value.(K)
+	if !p.hasPending || tail == p.lastAdded || len(p.elem) == 1 {
+		return tail, true
+	}
+	p.hasPending = false
+	if p.sketch.Estimate(keyToString(p.lastAdded)) > p.sketch.Estimate(keyToString(tail)) {
+		return tail, true
+	}
+	return p.lastAdded, true
+}
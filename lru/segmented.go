@@ -0,0 +1,168 @@
+package lru
+
+import "container/list"
+
+// Segmented LRU (SLRU) 用两个独立的队列抵抗"一次性扫描污染缓存"的问题：
+// 新写入的 key 先进入 probationary(试用)段，只有再次被命中才会晋升到
+// protected(保护)段；一次性扫描产生的大量 key 只访问一次，永远留在
+// probationary 段，淘汰时只会从 probationary 段的队尾开始，不会碰 protected
+// 段里真正的热点数据。
+
+// slruEntry 是 SegmentedCache 内部的一条记录
+type slruEntry struct {
+	key   string
+	value string
+}
+
+func (e *slruEntry) size() int {
+	return len(e.key) + len(e.value)
+}
+
+// SegmentedCache 是带 probationary/protected 两段队列的 LRU 变体
+type SegmentedCache struct {
+	maxBytes          int
+	protectedMaxBytes int // protected 段允许占用的最大字节数，超出时把队尾降级回 probationary
+
+	probation     *list.List
+	protected     *list.List
+	probationElem map[string]*list.Element
+	protectedElem map[string]*list.Element
+
+	probationUsed int
+	protectedUsed int
+
+	OnEvicted func(key, value string)
+}
+
+// NewSegmentedCache 实例化一个 SLRU 缓存，protectedRatio 是 protected 段占
+// maxBytes 的比例，取值应当在 (0,1) 之间，非法值退化为默认的 0.8。
+func NewSegmentedCache(maxBytes int, protectedRatio float64, onEvicted func(string, string)) *SegmentedCache {
+	if protectedRatio <= 0 || protectedRatio >= 1 {
+		protectedRatio = 0.8
+	}
+	return &SegmentedCache{
+		maxBytes:          maxBytes,
+		protectedMaxBytes: int(float64(maxBytes) * protectedRatio),
+		probation:         list.New(),
+		protected:         list.New(),
+		probationElem:     make(map[string]*list.Element),
+		protectedElem:     make(map[string]*list.Element),
+		OnEvicted:         onEvicted,
+	}
+}
+
+// Get 查询key所对应的value。命中 protected 段只是提升为 MRU；
+// 命中 probationary 段则把记录晋升进 protected 段的 MRU。
+func (c *SegmentedCache) Get(key string) (value string, ok bool) {
+	if ele, ok := c.protectedElem[key]; ok {
+		c.protected.MoveToFront(ele)
+		return ele.Value.(*slruEntry).value, true
+	}
+	ele, ok := c.probationElem[key]
+	if !ok {
+		return "", false
+	}
+	e := ele.Value.(*slruEntry)
+	c.probation.Remove(ele)
+	delete(c.probationElem, key)
+	c.probationUsed -= e.size()
+
+	c.protectedElem[key] = c.protected.PushFront(e)
+	c.protectedUsed += e.size()
+	c.demoteOverflow()
+	return e.value, true
+}
+
+// demoteOverflow 把超出 protectedMaxBytes 的 protected 队尾记录降级回 probationary 的 MRU。
+// maxBytes为0表示总容量不限，此时protected段也不设上限。
+func (c *SegmentedCache) demoteOverflow() {
+	if c.maxBytes == 0 {
+		return
+	}
+	for c.protectedUsed > c.protectedMaxBytes {
+		back := c.protected.Back()
+		if back == nil {
+			return
+		}
+		e := back.Value.(*slruEntry)
+		c.protected.Remove(back)
+		delete(c.protectedElem, e.key)
+		c.protectedUsed -= e.size()
+
+		c.probationElem[e.key] = c.probation.PushFront(e)
+		c.probationUsed += e.size()
+	}
+}
+
+// Add 写入一个key-value。已经在 protected 段的key原地更新；
+// 已经在 probationary 段的key按照命中处理，同样晋升进 protected 段；
+// 全新的key总是从 probationary 段的 MRU 进入。
+func (c *SegmentedCache) Add(key, value string) {
+	if ele, ok := c.protectedElem[key]; ok {
+		e := ele.Value.(*slruEntry)
+		c.protectedUsed += len(value) - len(e.value)
+		e.value = value
+		c.protected.MoveToFront(ele)
+		c.demoteOverflow()
+		c.evictOverCapacity()
+		return
+	}
+	if ele, ok := c.probationElem[key]; ok {
+		e := ele.Value.(*slruEntry)
+		oldSize := e.size()
+		e.value = value
+		newSize := e.size()
+
+		c.probation.Remove(ele)
+		delete(c.probationElem, key)
+		c.probationUsed -= oldSize
+
+		c.protectedElem[key] = c.protected.PushFront(e)
+		c.protectedUsed += newSize
+		c.demoteOverflow()
+		c.evictOverCapacity()
+		return
+	}
+	e := &slruEntry{key: key, value: value}
+	c.probationElem[key] = c.probation.PushFront(e)
+	c.probationUsed += e.size()
+	c.evictOverCapacity()
+}
+
+// evictOverCapacity 淘汰直到总字节数回到maxBytes以内，永远优先淘汰 probationary 段的队尾
+func (c *SegmentedCache) evictOverCapacity() {
+	for c.maxBytes != 0 && c.probationUsed+c.protectedUsed > c.maxBytes {
+		back := c.probation.Back()
+		fromProbation := true
+		if back == nil {
+			back = c.protected.Back()
+			fromProbation = false
+		}
+		if back == nil {
+			return
+		}
+		e := back.Value.(*slruEntry)
+		if fromProbation {
+			c.probation.Remove(back)
+			delete(c.probationElem, e.key)
+			c.probationUsed -= e.size()
+		} else {
+			c.protected.Remove(back)
+			delete(c.protectedElem, e.key)
+			c.protectedUsed -= e.size()
+		}
+		if c.OnEvicted != nil {
+			c.OnEvicted(e.key, e.value)
+		}
+	}
+}
+
+// Len 返回两个段加起来的记录数
+func (c *SegmentedCache) Len() int {
+	return c.probation.Len() + c.protected.Len()
+}
+
+// Bytes 返回两个段加起来占用的字节数
+func (c *SegmentedCache) Bytes() int {
+	return c.probationUsed + c.protectedUsed
+}
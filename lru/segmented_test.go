@@ -0,0 +1,140 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSegmentedCacheGetAdd(t *testing.T) {
+	c := NewSegmentedCache(1<<20, 0.8, nil)
+	c.Add("k1", "v1")
+	if v, ok := c.Get("k1"); !ok || v != "v1" {
+		t.Fatalf("Get(k1) = %v, %v; want v1, true", v, ok)
+	}
+}
+
+func TestSegmentedCacheUnboundedDoesNotDemoteOnPromotion(t *testing.T) {
+	// maxBytes=0 表示不限容量，protected 段也不应该因为容量检查被立刻打回 probationary
+	c := NewSegmentedCache(0, 0.8, nil)
+	c.Add("k1", "v1")
+	c.Get("k1") // 命中，晋升进 protected
+
+	if _, ok := c.protectedElem["k1"]; !ok {
+		t.Fatalf("k1 should stay in the protected segment when maxBytes is unbounded")
+	}
+}
+
+func TestSegmentedCacheGrowingProtectedEntryStaysWithinCap(t *testing.T) {
+	// protected 段上限只有10字节，刚好放得下"k1"+"short"，放不下后面变长的value
+	c := NewSegmentedCache(100, 0.1, nil)
+
+	c.Add("k1", "short")
+	c.Get("k1") // 晋升进 protected，此时大小7字节，没有超出10字节的上限
+
+	if _, ok := c.protectedElem["k1"]; !ok {
+		t.Fatalf("k1 should have been promoted to the protected segment")
+	}
+
+	// 原地增大 k1 的 value，让它的尺寸超出 protected 段的上限
+	c.Add("k1", "a-much-longer-value-than-before")
+
+	if _, ok := c.probationElem["k1"]; !ok {
+		t.Fatalf("growing an in-place protected entry past its cap should demote it back to probationary")
+	}
+}
+
+func TestSegmentedCacheResistsOneShotScan(t *testing.T) {
+	// protected 段容量只够放一个 key，probationary 段放不下全部 5 个一次性 key，扫描一定会触发淘汰
+	budget := len("hot") + len("v")
+	c := NewSegmentedCache(budget*6, 1.0/6.0, nil)
+
+	c.Add("hot", "v")
+	c.Get("hot") // 命中一次，晋升进 protected 段
+
+	for i := 0; i < 5; i++ {
+		c.Add(fmt.Sprintf("scan%d", i), "v") // 一次性扫描，只进入 probationary 段
+	}
+
+	if _, ok := c.Get("hot"); !ok {
+		t.Fatalf("hot key was evicted by a one-shot scan, SLRU should have protected it")
+	}
+}
+
+func TestSegmentedCacheEvictsProbationaryTailFirst(t *testing.T) {
+	var evicted []string
+	budget := len("k1") + len("v1")
+	c := NewSegmentedCache(budget*2, 0.8, func(key, _ string) {
+		evicted = append(evicted, key)
+	})
+
+	c.Add("k1", "v1") // 进入 probationary
+	c.Add("k2", "v2") // 进入 probationary，刚好填满容量
+	c.Add("k3", "v3") // 容量超限，应该淘汰 probationary 队尾 k1
+
+	if len(evicted) == 0 || evicted[0] != "k1" {
+		t.Fatalf("evicted = %v, want first eviction to be k1 (probationary tail)", evicted)
+	}
+}
+
+func benchmarkHitRateTrace(newCache func(maxBytes int) interface {
+	Get(string) (string, bool)
+	Add(string, string)
+}, maxBytes int) float64 {
+	keys := zipfKeys(150000, 8000)
+	// 在尾部混入一段一次性扫描，模拟真实流量里偶发的大范围扫描
+	for i := 0; i < 20000; i++ {
+		keys = append(keys, fmt.Sprintf("scan%d", i))
+	}
+
+	c := newCache(maxBytes)
+	hits, total := 0, 0
+	for _, key := range keys {
+		if _, ok := c.Get(key); ok {
+			hits++
+		} else {
+			c.Add(key, "0123456789ABCDEF")
+		}
+		total++
+	}
+	return float64(hits) / float64(total)
+}
+
+type lruAdapter struct{ c *Cache[string, String] }
+
+func (a lruAdapter) Get(key string) (string, bool) {
+	v, ok := a.c.Get(key)
+	return string(v), ok
+}
+func (a lruAdapter) Add(key, value string) { a.c.Add(key, String(value)) }
+
+// BenchmarkHitRateScanResistance 比较 SLRU 和普通 LRU 在同样容量、
+// 含一次性扫描的混合访问轨迹下的命中率
+func BenchmarkHitRateScanResistance(b *testing.B) {
+	const maxBytes = 1 << 13
+
+	b.Run("LRU", func(b *testing.B) {
+		var hitRate float64
+		for i := 0; i < b.N; i++ {
+			hitRate = benchmarkHitRateTrace(func(maxBytes int) interface {
+				Get(string) (string, bool)
+				Add(string, string)
+			} {
+				return lruAdapter{c: NewCache[string, String](maxBytes, nil)}
+			}, maxBytes)
+		}
+		b.ReportMetric(hitRate*100, "hit%")
+	})
+
+	b.Run("SLRU", func(b *testing.B) {
+		var hitRate float64
+		for i := 0; i < b.N; i++ {
+			hitRate = benchmarkHitRateTrace(func(maxBytes int) interface {
+				Get(string) (string, bool)
+				Add(string, string)
+			} {
+				return NewSegmentedCache(maxBytes, 0.8, nil)
+			}, maxBytes)
+		}
+		b.ReportMetric(hitRate*100, "hit%")
+	})
+}
@@ -0,0 +1,64 @@
+package lru
+
+import "time"
+
+// StringCache 保留了泛型化之前 Cache 的 string->string 行为，
+// 用来不破坏已经依赖这个签名的调用方(如 cache.ShardedCache)。
+type StringCache struct {
+	c *Cache[string, String]
+}
+
+// NewStringCache 实例化一个 StringCache，用法与泛型化之前的 NewCache 完全一致
+func NewStringCache(maxBytes int, onEvicted func(key, value string), opts ...Option[string, String]) *StringCache {
+	var wrapped func(string, String, EvictReason)
+	if onEvicted != nil {
+		wrapped = func(key string, value String, _ EvictReason) {
+			onEvicted(key, string(value))
+		}
+	}
+	return &StringCache{c: NewCache(maxBytes, wrapped, opts...)}
+}
+
+// Get 查询key所对应的value
+func (sc *StringCache) Get(key string) (value string, ok bool) {
+	v, ok := sc.c.Get(key)
+	return string(v), ok
+}
+
+// Peek 查询key所对应的value，但不会提升为MRU
+func (sc *StringCache) Peek(key string) (value string, ok bool) {
+	v, ok := sc.c.Peek(key)
+	return string(v), ok
+}
+
+// Add 写入一个key-value
+func (sc *StringCache) Add(key, value string) {
+	sc.c.Add(key, String(value))
+}
+
+// AddWithTTL 写入一个key-value，并在ttl之后让它自动失效
+func (sc *StringCache) AddWithTTL(key, value string, ttl time.Duration) {
+	sc.c.AddWithTTL(key, String(value), ttl)
+}
+
+// Remove 主动删除一个key，存在则返回true
+func (sc *StringCache) Remove(key string) bool {
+	return sc.c.Remove(key)
+}
+
+// Len the number of cache entries
+func (sc *StringCache) Len() int {
+	return sc.c.Len()
+}
+
+// Bytes 返回当前缓存占用的字节数
+func (sc *StringCache) Bytes() int {
+	return sc.c.Bytes()
+}
+
+// Range 遍历缓存中的每一项，不保证顺序；f返回false时提前终止遍历
+func (sc *StringCache) Range(f func(key, value string) bool) {
+	sc.c.Range(func(key string, value String) bool {
+		return f(key, string(value))
+	})
+}
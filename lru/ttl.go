@@ -0,0 +1,137 @@
+package lru
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// EvictReason 说明一条记录为什么被淘汰
+type EvictReason int
+
+const (
+	// EvictCapacity 因为超出 maxBytes，被非 LRU 的淘汰策略（FIFO/LRU-K/TinyLFU/...）选中淘汰
+	EvictCapacity EvictReason = iota
+	// EvictTTL entry 的 TTL 到期，被 Get 或 Janitor 惰性淘汰
+	EvictTTL
+	// EvictExplicit 调用方主动调用 Remove
+	EvictExplicit
+	// EvictLRU 因为超出 maxBytes，被经典 LRU 策略选中淘汰；是 EvictCapacity 的细化，
+	// 方便调用方在使用默认策略时区分出"按最近最少使用淘汰"这个具体原因
+	EvictLRU
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictTTL:
+		return "ttl"
+	case EvictExplicit:
+		return "explicit"
+	case EvictLRU:
+		return "lru"
+	default:
+		return "unknown"
+	}
+}
+
+// ttlEntry 记录一个 key 的到期时间，用于按到期时间排序的最小堆
+type ttlEntry[K comparable] struct {
+	key       K
+	expiresAt time.Time
+	index     int
+}
+
+// ttlHeap 是按 expiresAt 升序排列的最小堆，堆顶就是最快到期的 key
+type ttlHeap[K comparable] []*ttlEntry[K]
+
+func (h ttlHeap[K]) Len() int           { return len(h) }
+func (h ttlHeap[K]) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h ttlHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *ttlHeap[K]) Push(x interface{}) {
+	e := x.(*ttlEntry[K])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *ttlHeap[K]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+// setExpiryLocked 更新key在TTL堆里的到期时间；expiresAt为零值表示永不过期
+func (c *Cache[K, V]) setExpiryLocked(key K, expiresAt time.Time) {
+	if e, ok := c.ttlIndex[key]; ok {
+		if expiresAt.IsZero() {
+			heap.Remove(&c.ttl, e.index)
+			delete(c.ttlIndex, key)
+			return
+		}
+		e.expiresAt = expiresAt
+		heap.Fix(&c.ttl, e.index)
+		return
+	}
+	if expiresAt.IsZero() {
+		return
+	}
+	e := &ttlEntry[K]{key: key, expiresAt: expiresAt}
+	heap.Push(&c.ttl, e)
+	c.ttlIndex[key] = e
+}
+
+// clearExpiryLocked 把key从TTL堆里移除
+func (c *Cache[K, V]) clearExpiryLocked(key K) {
+	if e, ok := c.ttlIndex[key]; ok {
+		heap.Remove(&c.ttl, e.index)
+		delete(c.ttlIndex, key)
+	}
+}
+
+// expiredLocked 判断key当前是否已经过期
+func (c *Cache[K, V]) expiredLocked(key K) bool {
+	e, ok := c.ttlIndex[key]
+	return ok && time.Now().After(e.expiresAt)
+}
+
+// StartJanitor 启动一个后台协程，每隔interval扫描一次过期记录。
+// 借助按expiresAt排序的最小堆，每轮只需要弹出真正已经过期的那部分记录，
+// 复杂度是 O(k log n)，k 是本轮过期的记录数，而不必扫描整个缓存。
+// 调用返回的 stop 可以停止该协程，可以重复调用。
+func (c *Cache[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				c.sweepExpired()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// sweepExpired 淘汰所有已经到期的记录
+func (c *Cache[K, V]) sweepExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for len(c.ttl) > 0 && !c.ttl[0].expiresAt.After(now) {
+		c.removeLocked(c.ttl[0].key, EvictTTL)
+	}
+}
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAddWithTTLExpiresAsMiss(t *testing.T) {
+	var reasons []EvictReason
+	c := NewCache[string, String](0, func(_ string, _ String, reason EvictReason) {
+		reasons = append(reasons, reason)
+	})
+	c.AddWithTTL("k1", "v1", 10*time.Millisecond)
+
+	if v, ok := c.Get("k1"); !ok || v != "v1" {
+		t.Fatalf("Get(k1) before expiry = %v, %v; want v1, true", v, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("k1"); ok {
+		t.Fatalf("Get(k1) after expiry should miss")
+	}
+	if len(reasons) != 1 || reasons[0] != EvictTTL {
+		t.Fatalf("reasons = %v, want [EvictTTL]", reasons)
+	}
+}
+
+func TestAddWithoutTTLNeverExpires(t *testing.T) {
+	c := NewCache[string, String](0, nil)
+	c.Add("k1", "v1")
+	time.Sleep(10 * time.Millisecond)
+	if _, ok := c.Get("k1"); !ok {
+		t.Fatalf("Get(k1) should not expire when added via Add (no ttl)")
+	}
+}
+
+func TestRemoveFiresExplicitReason(t *testing.T) {
+	var reason EvictReason
+	c := NewCache[string, String](0, func(_ string, _ String, r EvictReason) {
+		reason = r
+	})
+	c.Add("k1", "v1")
+	if !c.Remove("k1") {
+		t.Fatalf("Remove(k1) = false, want true")
+	}
+	if reason != EvictExplicit {
+		t.Fatalf("reason = %v, want EvictExplicit", reason)
+	}
+}
+
+func TestCapacityEvictionReportsEvictLRUForDefaultPolicy(t *testing.T) {
+	var reason EvictReason
+	c := NewCache[string, String](int(len("k1")+len("v1")), func(_ string, _ String, r EvictReason) {
+		reason = r
+	})
+	c.Add("k1", "v1")
+	c.Add("k2", "v2") // 容量只够放一个key，k1被经典LRU策略淘汰
+
+	if reason != EvictLRU {
+		t.Fatalf("reason = %v, want EvictLRU", reason)
+	}
+}
+
+func TestCapacityEvictionReportsEvictCapacityForNonLRUPolicy(t *testing.T) {
+	var reason EvictReason
+	c := NewCache[string, String](int(len("k1")+len("v1")), func(_ string, _ String, r EvictReason) {
+		reason = r
+	}, WithPolicy[string, String](NewFIFOPolicy[string]()))
+	c.Add("k1", "v1")
+	c.Add("k2", "v2") // 容量只够放一个key，k1被FIFO策略淘汰
+
+	if reason != EvictCapacity {
+		t.Fatalf("reason = %v, want EvictCapacity", reason)
+	}
+}
+
+func TestStartJanitorSweepsExpiredEntries(t *testing.T) {
+	var mu sync.Mutex
+	var evicted []string
+	c := NewCache[string, String](0, func(key string, _ String, reason EvictReason) {
+		if reason == EvictTTL {
+			mu.Lock()
+			evicted = append(evicted, key)
+			mu.Unlock()
+		}
+	})
+	c.AddWithTTL("k1", "v1", 5*time.Millisecond)
+
+	stop := c.StartJanitor(5 * time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for {
+		mu.Lock()
+		n := len(evicted)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(evicted) != 1 || evicted[0] != "k1" {
+		t.Fatalf("janitor did not sweep expired entry, evicted = %v", evicted)
+	}
+}
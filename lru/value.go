@@ -0,0 +1,56 @@
+package lru
+
+// Value 是可以被缓存的值需要满足的约束：缓存只关心一个值占用多少字节，
+// 具体怎么计算由值自己决定，这样任意类型(字节切片、结构体、protobuf消息...)
+// 都可以被缓存并参与精确的内存记账。
+type Value interface {
+	Len() int
+}
+
+// String 是 string 的 Value 适配器
+type String string
+
+// Len 返回字符串的字节数
+func (s String) Len() int {
+	return len(s)
+}
+
+// Bytes 是 []byte 的 Value 适配器
+type Bytes []byte
+
+// Len 返回字节切片的长度
+func (b Bytes) Len() int {
+	return len(b)
+}
+
+// ByteView 持有一份只读的字节数据拷贝，对外暴露的都是副本，
+// 防止缓存内部的数据被调用方意外修改。
+type ByteView struct {
+	b []byte
+}
+
+// NewByteView 拷贝一份 b 构造出一个 ByteView
+func NewByteView(b []byte) ByteView {
+	return ByteView{b: cloneBytes(b)}
+}
+
+// Len 返回底层字节数据的长度
+func (v ByteView) Len() int {
+	return len(v.b)
+}
+
+// ByteSlice 返回底层字节数据的一份拷贝
+func (v ByteView) ByteSlice() []byte {
+	return cloneBytes(v.b)
+}
+
+// String 以字符串形式返回底层字节数据
+func (v ByteView) String() string {
+	return string(v.b)
+}
+
+func cloneBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,28 @@
+package lru
+
+import "testing"
+
+func TestByteViewIsImmutable(t *testing.T) {
+	b := []byte("hello")
+	v := NewByteView(b)
+	b[0] = 'H' // 修改原始切片不应该影响 ByteView 内部的数据
+
+	if v.String() != "hello" {
+		t.Fatalf("v.String() = %q, want %q", v.String(), "hello")
+	}
+
+	out := v.ByteSlice()
+	out[0] = 'H' // 修改返回的切片也不应该影响 ByteView 内部的数据
+	if v.String() != "hello" {
+		t.Fatalf("ByteSlice() leaked internal storage, v.String() = %q", v.String())
+	}
+}
+
+func TestGenericCacheWithByteView(t *testing.T) {
+	c := NewCache[string, ByteView](1<<20, nil)
+	c.Add("k1", NewByteView([]byte("v1")))
+	v, ok := c.Get("k1")
+	if !ok || v.String() != "v1" {
+		t.Fatalf("Get(k1) = %v, %v; want v1, true", v, ok)
+	}
+}